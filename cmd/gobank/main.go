@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+
+	"github.com/FocusThen/gobank/pkg/api"
+	"github.com/FocusThen/gobank/pkg/store"
+)
+
+func main() {
+	s, err := store.NewPostgresStore()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := s.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	server := api.NewAPIServer(":3000", s)
+	server.Run()
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+
+	"github.com/FocusThen/gobank/pkg/auth"
+	"github.com/FocusThen/gobank/pkg/store"
+	"github.com/FocusThen/gobank/pkg/types"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+var validate = validator.New()
+
+type APIServer struct {
+	listenAddr string
+	store      store.Storage
+}
+
+func NewAPIServer(listenAddr string, s store.Storage) *APIServer {
+	return &APIServer{
+		listenAddr: listenAddr,
+		store:      s,
+	}
+}
+
+func (s *APIServer) Run() {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/login", makeHandleFunc(s.handleLogin)).Methods(http.MethodPost)
+	router.HandleFunc("/account", makeHandleFunc(s.handleCreateAccount)).Methods(http.MethodPost)
+	router.HandleFunc("/account", auth.RequireRole("admin", makeHandleFunc(s.handleGetAccount), s.store)).Methods(http.MethodGet)
+	router.HandleFunc("/account/{id}", auth.WithJWTAuth(makeHandleFunc(s.handleGetAccountByID), s.store)).Methods(http.MethodGet)
+	router.HandleFunc("/account/{id}", auth.WithJWTAuth(makeHandleFunc(s.handleUpdateAccount), s.store)).Methods(http.MethodPut)
+	router.HandleFunc("/account/{id}", auth.RequireRole("admin", makeHandleFunc(s.handleDeleteAccount), s.store)).Methods(http.MethodDelete)
+	router.HandleFunc("/transfer", auth.RequireToken(makeHandleFunc(s.handleTransfer)))
+
+	log.Println("JSON API server running on port: ", s.listenAddr)
+
+	http.ListenAndServe(s.listenAddr, router)
+}
+
+func (s *APIServer) handleTransfer(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case "GET":
+		return s.handleGetTransfers(w, r)
+	case "PUT":
+		return s.handleTransferToAccount(w, r)
+	default:
+		return types.MethodNotAllowedError(r.Method)
+	}
+}
+
+// LOGIN
+func (s *APIServer) handleLogin(w http.ResponseWriter, r *http.Request) error {
+	loginRequest := new(types.LoginRequest)
+	if err := json.NewDecoder(r.Body).Decode(loginRequest); err != nil {
+		return err
+	}
+
+	account, err := s.store.GetAccountByNumber(loginRequest.Number)
+	if err != nil {
+		return err
+	}
+
+	if !account.ValidPassword(loginRequest.Password) {
+		return types.UnauthorizedError("invalid credentials")
+	}
+
+	tokenString, err := auth.CreateJWT(account)
+	if err != nil {
+		return err
+	}
+
+	return types.WriteJSON(w, http.StatusOK, map[string]string{"token": tokenString})
+}
+
+// GET
+func (s *APIServer) handleGetAccount(w http.ResponseWriter, r *http.Request) error {
+	limit, page, err := parsePagination(r)
+	if err != nil {
+		return err
+	}
+	offset := (page - 1) * limit
+
+	keyword := r.URL.Query().Get("search")
+
+	var accounts []*types.Account
+	var total int
+	if keyword != "" {
+		accounts, total, err = s.store.SearchAccounts(keyword, limit, offset)
+	} else {
+		accounts, total, err = s.store.GetAccounts(limit, offset)
+	}
+	if err != nil {
+		return err
+	}
+
+	return types.WriteJSON(w, http.StatusOK, types.PaginatedResponse{Data: accounts, Page: page, Total: total})
+}
+
+func (s *APIServer) handleGetAccountByID(w http.ResponseWriter, r *http.Request) error {
+	id, err := getId(r)
+	if err != nil {
+		return err
+	}
+
+	account, err := s.store.GetAccountByID(id)
+	if err != nil {
+		return err
+	}
+	return types.WriteJSON(w, http.StatusOK, account)
+}
+
+// CREATE
+func (s *APIServer) handleCreateAccount(w http.ResponseWriter, r *http.Request) error {
+	createAccount := new(types.CreateAccountRequest)
+	if err := json.NewDecoder(r.Body).Decode(createAccount); err != nil {
+		return err
+	}
+	if err := validateStruct(createAccount); err != nil {
+		return err
+	}
+
+	account, err := types.NewAccount(createAccount.FirstName, createAccount.LastName, createAccount.Password)
+	if err != nil {
+		return err
+	}
+	if err := s.store.CreateAccount(*account); err != nil {
+		return err
+	}
+
+	return types.WriteJSON(w, http.StatusOK, account)
+}
+
+// UPDATE
+func (s *APIServer) handleUpdateAccount(w http.ResponseWriter, r *http.Request) error {
+	id, err := getId(r)
+	if err != nil {
+		return err
+	}
+	reqAccount := new(types.CreateAccountRequest)
+	if err := json.NewDecoder(r.Body).Decode(reqAccount); err != nil {
+		return err
+	}
+	account := types.Account{ID: id, FirstName: reqAccount.FirstName, LastName: reqAccount.LastName}
+
+	updatedAccount, err := s.store.UpdateAccount(account)
+	if err != nil {
+		return err
+	}
+
+	return types.WriteJSON(w, http.StatusOK, updatedAccount)
+}
+
+// DELETE
+func (s *APIServer) handleDeleteAccount(w http.ResponseWriter, r *http.Request) error {
+	id, err := getId(r)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteAccount(id); err != nil {
+		return err
+	}
+	return types.WriteJSON(w, http.StatusOK, map[string]int{"deleted": id})
+}
+
+// Transfer
+func (s *APIServer) handleTransferToAccount(w http.ResponseWriter, r *http.Request) error {
+	transfer := new(types.TransferRequest)
+	if err := json.NewDecoder(r.Body).Decode(transfer); err != nil {
+		return err
+	}
+	if err := validateStruct(transfer); err != nil {
+		return err
+	}
+	if err := s.authorizeAccount(r, transfer.FromAccount); err != nil {
+		return err
+	}
+
+	result, err := s.store.TransferToAccount(*transfer)
+	if err != nil {
+		return err
+	}
+
+	return types.WriteJSON(w, http.StatusOK, result)
+}
+
+func (s *APIServer) handleGetTransfers(w http.ResponseWriter, r *http.Request) error {
+	accountStr := r.URL.Query().Get("account")
+	accountID, err := strconv.Atoi(accountStr)
+	if err != nil {
+		return types.InvalidRequestError(map[string]string{"account": "must be a valid account id"})
+	}
+	if err := s.authorizeAccount(r, accountID); err != nil {
+		return err
+	}
+
+	transfers, err := s.store.GetTransfersByAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	return types.WriteJSON(w, http.StatusOK, transfers)
+}
+
+// authorizeAccount ensures the caller's JWT belongs to accountID, so a
+// token for one account can't move money out of or read the ledger for
+// another. Admins are exempt, matching the admin-bypasses-ownership rule
+// RequireRole already applies to the /account routes.
+func (s *APIServer) authorizeAccount(r *http.Request, accountID int) error {
+	claims, err := auth.ClaimsFromRequest(r)
+	if err != nil {
+		return types.UnauthorizedError("invalid credentials")
+	}
+	if claims.Role == "admin" {
+		return nil
+	}
+
+	account, err := s.store.GetAccountByID(accountID)
+	if err != nil {
+		return err
+	}
+	if account.Number != claims.AccountNumber {
+		return types.UnauthorizedError("account does not belong to the caller")
+	}
+
+	return nil
+}
+
+type apiFunc func(http.ResponseWriter, *http.Request) error
+
+func makeHandleFunc(f apiFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := f(w, r)
+		if err == nil {
+			return
+		}
+
+		apiErr, ok := err.(*types.APIError)
+		if !ok {
+			apiErr = types.NewAPIError(http.StatusBadRequest, "bad_request", err.Error())
+		}
+
+		types.WriteJSON(w, apiErr.Status, apiErr)
+	}
+}
+
+func getId(r *http.Request) (int, error) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return id, types.NewAPIError(http.StatusBadRequest, "invalid_request", fmt.Sprintf("invalid id given %s", idStr))
+	}
+	return id, nil
+}
+
+// validateStruct runs the struct tag validations on v and turns any failure
+// into an *APIError carrying one message per offending field.
+func validateStruct(v any) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	fields := map[string]string{}
+	for _, fieldErr := range err.(validator.ValidationErrors) {
+		fields[fieldErr.Field()] = fieldErr.Tag()
+	}
+
+	return types.InvalidRequestError(fields)
+}
+
+// parsePagination reads ?limit= and ?page= off the request, defaulting to a
+// single page of defaultPageLimit accounts and rejecting a limit above
+// maxPageLimit so a caller can't force an unbounded result page.
+func parsePagination(r *http.Request) (limit, page int, err error) {
+	limit = defaultPageLimit
+	page = 1
+
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > maxPageLimit {
+			return 0, 0, types.InvalidRequestError(map[string]string{"limit": fmt.Sprintf("must be an integer between 1 and %d", maxPageLimit)})
+		}
+	}
+
+	if v := r.URL.Query().Get("page"); v != "" {
+		page, err = strconv.Atoi(v)
+		if err != nil || page <= 0 {
+			return 0, 0, types.InvalidRequestError(map[string]string{"page": "must be a positive integer"})
+		}
+	}
+
+	return limit, page, nil
+}
@@ -0,0 +1,702 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+
+	"github.com/FocusThen/gobank/pkg/auth"
+	"github.com/FocusThen/gobank/pkg/store/mock"
+	"github.com/FocusThen/gobank/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	os.Exit(m.Run())
+}
+
+func newJSONRequest(t *testing.T, method, target string, body any) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("encode request body: %v", err)
+		}
+	}
+
+	return httptest.NewRequest(method, target, &buf)
+}
+
+func TestHandleCreateAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       types.CreateAccountRequest
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: types.CreateAccountRequest{FirstName: "Jane", LastName: "Doe", Password: "supersecret"},
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().CreateAccount(gomock.Any()).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing fields fails validation",
+			body:       types.CreateAccountRequest{},
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "store error bubbles up as bad request",
+			body: types.CreateAccountRequest{FirstName: "Jane", LastName: "Doe", Password: "supersecret"},
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().CreateAccount(gomock.Any()).Return(assertErr)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := newJSONRequest(t, http.MethodPost, "/account", tt.body)
+
+			makeHandleFunc(server.handleCreateAccount)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleLogin(t *testing.T) {
+	account, err := types.NewAccount("Jane", "Doe", "supersecret")
+	if err != nil {
+		t.Fatalf("NewAccount: %v", err)
+	}
+	account.ID = 1
+
+	tests := []struct {
+		name       string
+		body       types.LoginRequest
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: types.LoginRequest{Number: account.Number, Password: "supersecret"},
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByNumber(account.Number).Return(account, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "wrong password is unauthorized",
+			body: types.LoginRequest{Number: account.Number, Password: "wrong-password"},
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByNumber(account.Number).Return(account, nil)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "unknown account is not found",
+			body: types.LoginRequest{Number: 999, Password: "supersecret"},
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByNumber(int64(999)).Return(nil, types.NotFoundError("account with number 999 not found"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := newJSONRequest(t, http.MethodPost, "/login", tt.body)
+
+			makeHandleFunc(server.handleLogin)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleGetAccountByID(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(1).Return(&types.Account{ID: 1}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "not found",
+			id:   "2",
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(2).Return(nil, types.NotFoundError("account 2 not found"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid id",
+			id:         "not-an-id",
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/account/"+tt.id, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			makeHandleFunc(server.handleGetAccountByID)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleTransferToAccount(t *testing.T) {
+	ownerToken := mustToken(t, 42, "user")
+	strangerToken := mustToken(t, 999, "user")
+
+	tests := []struct {
+		name       string
+		body       types.TransferRequest
+		token      string
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			body:  types.TransferRequest{FromAccount: 1, ToAccount: 2, Amount: 500},
+			token: ownerToken,
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(1).Return(&types.Account{ID: 1, Number: 42}, nil)
+				ms.EXPECT().TransferToAccount(types.TransferRequest{FromAccount: 1, ToAccount: 2, Amount: 500}).
+					Return(&types.TransferResult{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "same account fails validation",
+			body:       types.TransferRequest{FromAccount: 1, ToAccount: 1, Amount: 500},
+			token:      ownerToken,
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "transfer from an account the caller doesn't own is unauthorized",
+			body:  types.TransferRequest{FromAccount: 1, ToAccount: 2, Amount: 500},
+			token: strangerToken,
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(1).Return(&types.Account{ID: 1, Number: 42}, nil)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "insufficient funds",
+			body:  types.TransferRequest{FromAccount: 1, ToAccount: 2, Amount: 500},
+			token: ownerToken,
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(1).Return(&types.Account{ID: 1, Number: 42}, nil)
+				ms.EXPECT().TransferToAccount(types.TransferRequest{FromAccount: 1, ToAccount: 2, Amount: 500}).
+					Return(nil, types.InsufficientFundsError("account 1 has insufficient funds"))
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := newJSONRequest(t, http.MethodPut, "/transfer", tt.body)
+			req.Header.Set("x-jwt-token", tt.token)
+
+			makeHandleFunc(server.handleTransferToAccount)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleGetAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name:  "success lists accounts with default pagination",
+			query: "",
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccounts(defaultPageLimit, 0).Return([]*types.Account{{ID: 1}}, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "search keyword routes to SearchAccounts",
+			query: "?search=jane&limit=5&page=2",
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().SearchAccounts("jane", 5, 5).Return([]*types.Account{{ID: 1}}, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid limit fails validation",
+			query:      "?limit=0",
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid page fails validation",
+			query:      "?page=-1",
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "limit above the max fails validation",
+			query:      "?limit=100000000",
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "store error bubbles up as bad request",
+			query: "",
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccounts(defaultPageLimit, 0).Return(nil, 0, assertErr)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/account"+tt.query, nil)
+
+			makeHandleFunc(server.handleGetAccount)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleUpdateAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		body       types.CreateAccountRequest
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			body: types.CreateAccountRequest{FirstName: "Jane", LastName: "Smith"},
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().UpdateAccount(types.Account{ID: 1, FirstName: "Jane", LastName: "Smith"}).
+					Return(&types.Account{ID: 1, FirstName: "Jane", LastName: "Smith"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid id",
+			id:         "not-an-id",
+			body:       types.CreateAccountRequest{FirstName: "Jane", LastName: "Smith"},
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			id:   "2",
+			body: types.CreateAccountRequest{FirstName: "Jane", LastName: "Smith"},
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().UpdateAccount(types.Account{ID: 2, FirstName: "Jane", LastName: "Smith"}).
+					Return(nil, types.NotFoundError("account 2 not found"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := newJSONRequest(t, http.MethodPut, "/account/"+tt.id, tt.body)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			makeHandleFunc(server.handleUpdateAccount)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleDeleteAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().DeleteAccount(1).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid id",
+			id:         "not-an-id",
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "store error bubbles up as bad request",
+			id:   "1",
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().DeleteAccount(1).Return(assertErr)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/account/"+tt.id, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			makeHandleFunc(server.handleDeleteAccount)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleGetTransfers(t *testing.T) {
+	ownerToken := mustToken(t, 42, "user")
+	strangerToken := mustToken(t, 999, "user")
+	adminToken := mustToken(t, 7, "admin")
+
+	tests := []struct {
+		name       string
+		query      string
+		token      string
+		setupMock  func(*mock_store.MockStorage)
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			query: "?account=1",
+			token: ownerToken,
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(1).Return(&types.Account{ID: 1, Number: 42}, nil)
+				ms.EXPECT().GetTransfersByAccount(1).Return([]*types.Transfer{{ID: 1}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing account param fails validation",
+			query:      "",
+			token:      ownerToken,
+			setupMock:  func(ms *mock_store.MockStorage) {},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "reading another account's ledger is unauthorized",
+			query: "?account=1",
+			token: strangerToken,
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(1).Return(&types.Account{ID: 1, Number: 42}, nil)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "admin can read any account's ledger",
+			query: "?account=1",
+			token: adminToken,
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetTransfersByAccount(1).Return([]*types.Transfer{{ID: 1}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "store error bubbles up as bad request",
+			query: "?account=1",
+			token: ownerToken,
+			setupMock: func(ms *mock_store.MockStorage) {
+				ms.EXPECT().GetAccountByID(1).Return(&types.Account{ID: 1, Number: 42}, nil)
+				ms.EXPECT().GetTransfersByAccount(1).Return(nil, assertErr)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mock_store.NewMockStorage(ctrl)
+			tt.setupMock(ms)
+
+			server := NewAPIServer(":0", ms)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/transfer"+tt.query, nil)
+			req.Header.Set("x-jwt-token", tt.token)
+
+			makeHandleFunc(server.handleGetTransfers)(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+// TestRouteAuthWiring exercises the auth.RequireRole/auth.WithJWTAuth wiring
+// the same way Run() assembles it, rather than calling the handlers
+// directly, so a route that loses its middleware wrapping fails here.
+// mustToken signs a JWT for a caller with the given account number and
+// role, failing the test if signing errors.
+func mustToken(t *testing.T, number int64, role string) string {
+	t.Helper()
+
+	token, err := auth.CreateJWT(&types.Account{Number: number, Role: role})
+	if err != nil {
+		t.Fatalf("CreateJWT: %v", err)
+	}
+	return token
+}
+
+func TestRouteAuthWiring(t *testing.T) {
+	ownerAccount := &types.Account{ID: 1, Number: 42, Role: "user"}
+
+	ownerToken := mustToken(t, ownerAccount.Number, ownerAccount.Role)
+	strangerToken := mustToken(t, 999, "user")
+	adminToken := mustToken(t, 1, "admin")
+
+	t.Run("WithJWTAuth", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			token      string
+			setupMock  func(*mock_store.MockStorage)
+			wantStatus int
+		}{
+			{
+				name:       "no token is forbidden",
+				token:      "",
+				setupMock:  func(ms *mock_store.MockStorage) {},
+				wantStatus: http.StatusForbidden,
+			},
+			{
+				name:  "mismatched account is forbidden",
+				token: strangerToken,
+				setupMock: func(ms *mock_store.MockStorage) {
+					ms.EXPECT().GetAccountByID(1).Return(ownerAccount, nil)
+				},
+				wantStatus: http.StatusForbidden,
+			},
+			{
+				name:  "owner reaches the handler",
+				token: ownerToken,
+				setupMock: func(ms *mock_store.MockStorage) {
+					// once for WithJWTAuth's ownership check, once for the handler itself
+					ms.EXPECT().GetAccountByID(1).Return(ownerAccount, nil).Times(2)
+				},
+				wantStatus: http.StatusOK,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				ms := mock_store.NewMockStorage(ctrl)
+				tt.setupMock(ms)
+
+				handler := auth.WithJWTAuth(makeHandleFunc(NewAPIServer(":0", ms).handleGetAccountByID), ms)
+
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, "/account/1", nil)
+				req = mux.SetURLVars(req, map[string]string{"id": "1"})
+				if tt.token != "" {
+					req.Header.Set("x-jwt-token", tt.token)
+				}
+
+				handler(rec, req)
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+				}
+			})
+		}
+	})
+
+	t.Run("RequireRole", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			token      string
+			setupMock  func(*mock_store.MockStorage)
+			wantStatus int
+		}{
+			{
+				name:       "no token is forbidden",
+				token:      "",
+				setupMock:  func(ms *mock_store.MockStorage) {},
+				wantStatus: http.StatusForbidden,
+			},
+			{
+				name:       "non-admin role is forbidden",
+				token:      ownerToken,
+				setupMock:  func(ms *mock_store.MockStorage) {},
+				wantStatus: http.StatusForbidden,
+			},
+			{
+				name:  "admin reaches the handler",
+				token: adminToken,
+				setupMock: func(ms *mock_store.MockStorage) {
+					ms.EXPECT().GetAccounts(defaultPageLimit, 0).Return([]*types.Account{}, 0, nil)
+				},
+				wantStatus: http.StatusOK,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				ms := mock_store.NewMockStorage(ctrl)
+				tt.setupMock(ms)
+
+				handler := auth.RequireRole("admin", makeHandleFunc(NewAPIServer(":0", ms).handleGetAccount), ms)
+
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, "/account", nil)
+				if tt.token != "" {
+					req.Header.Set("x-jwt-token", tt.token)
+				}
+
+				handler(rec, req)
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+				}
+			})
+		}
+	})
+
+	t.Run("RequireToken", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			token      string
+			setupMock  func(*mock_store.MockStorage)
+			wantStatus int
+		}{
+			{
+				name:       "no token is forbidden",
+				token:      "",
+				setupMock:  func(ms *mock_store.MockStorage) {},
+				wantStatus: http.StatusForbidden,
+			},
+			{
+				name:  "valid token reaches the handler",
+				token: ownerToken,
+				setupMock: func(ms *mock_store.MockStorage) {
+					ms.EXPECT().GetAccountByID(1).Return(ownerAccount, nil)
+					ms.EXPECT().GetTransfersByAccount(1).Return([]*types.Transfer{}, nil)
+				},
+				wantStatus: http.StatusOK,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				ms := mock_store.NewMockStorage(ctrl)
+				tt.setupMock(ms)
+
+				handler := auth.RequireToken(makeHandleFunc(NewAPIServer(":0", ms).handleTransfer))
+
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, "/transfer?account=1", nil)
+				if tt.token != "" {
+					req.Header.Set("x-jwt-token", tt.token)
+				}
+
+				handler(rec, req)
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("got status %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+				}
+			})
+		}
+	})
+}
+
+var assertErr = types.NewAPIError(http.StatusBadRequest, "bad_request", "boom")
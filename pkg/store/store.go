@@ -0,0 +1,359 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
+
+	"github.com/FocusThen/gobank/pkg/types"
+)
+
+const migrationsPath = "file://db/migration"
+
+type Storage interface {
+	GetAccounts(limit, offset int) ([]*types.Account, int, error)
+	SearchAccounts(keyword string, limit, offset int) ([]*types.Account, int, error)
+	GetAccountByID(int) (*types.Account, error)
+	GetAccountByNumber(int64) (*types.Account, error)
+	CreateAccount(types.Account) error
+	UpdateAccount(types.Account) (*types.Account, error)
+	DeleteAccount(int) error
+	TransferToAccount(types.TransferRequest) (*types.TransferResult, error)
+	GetTransfersByAccount(int) ([]*types.Transfer, error)
+}
+
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore() (*PostgresStore, error) {
+	// do not do this, working with locally
+	connStr := "user=postgres dbname=postgres password=gobank sslmode=disable"
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return &PostgresStore{
+		db: db,
+	}, nil
+}
+
+func (s *PostgresStore) Init() error {
+	if err := s.runMigrations(); err != nil {
+		return err
+	}
+
+	return s.seedAdminAccount()
+}
+
+func (s *PostgresStore) runMigrations() error {
+	driver, err := postgres.WithInstance(s.db, &postgres.Config{})
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(migrationsPath, "postgres", driver)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	return nil
+}
+
+// seedAdminAccount creates the first admin account from ADMIN_API_KEY when
+// the account table is empty, so there's always a way to reach the
+// admin-only routes on a fresh database.
+func (s *PostgresStore) seedAdminAccount() error {
+	var count int
+	if err := s.db.QueryRow("select count(*) from account").Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	apiKey := os.Getenv("ADMIN_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("ADMIN_API_KEY must be set to seed the admin account")
+	}
+
+	admin, err := types.NewAdminAccount(apiKey)
+	if err != nil {
+		return err
+	}
+
+	if err := s.CreateAccount(*admin); err != nil {
+		return err
+	}
+
+	log.Printf("seeded admin account, number=%d", admin.Number)
+	return nil
+}
+
+func (s *PostgresStore) GetAccounts(limit, offset int) ([]*types.Account, int, error) {
+	var total int
+	if err := s.db.QueryRow("select count(*) from account").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query("select * from account order by id limit $1 offset $2", limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	accounts := []*types.Account{}
+	for rows.Next() {
+		account, err := scanIntoAccount(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts, total, nil
+}
+
+func (s *PostgresStore) SearchAccounts(keyword string, limit, offset int) ([]*types.Account, int, error) {
+	pattern := "%" + keyword + "%"
+
+	var total int
+	if err := s.db.QueryRow(
+		"select count(*) from account where first_name ilike $1 or last_name ilike $1",
+		pattern,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(
+		`select * from account
+		 where first_name ilike $1 or last_name ilike $1
+		 order by id limit $2 offset $3`,
+		pattern, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	accounts := []*types.Account{}
+	for rows.Next() {
+		account, err := scanIntoAccount(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts, total, nil
+}
+
+func (s *PostgresStore) GetAccountByID(id int) (*types.Account, error) {
+	rows, err := s.db.Query("select * from account where id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		return scanIntoAccount(rows)
+	}
+	return nil, types.NotFoundError(fmt.Sprintf("account %d not found", id))
+}
+
+func (s *PostgresStore) GetAccountByNumber(number int64) (*types.Account, error) {
+	rows, err := s.db.Query("select * from account where number = $1", number)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		return scanIntoAccount(rows)
+	}
+	return nil, types.NotFoundError(fmt.Sprintf("account with number %d not found", number))
+}
+
+func (s *PostgresStore) CreateAccount(account types.Account) error {
+	query := `insert into
+  account (
+      first_name,
+      last_name,
+      number,
+      balance,
+      password_hash,
+      role,
+      created_at)
+  values
+  ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := s.db.Query(query,
+		account.FirstName,
+		account.LastName,
+		account.Number,
+		account.Balance,
+		account.Password,
+		account.Role,
+		account.CreateAt)
+
+	return err
+}
+
+func (s *PostgresStore) UpdateAccount(account types.Account) (*types.Account, error) {
+	_, err := s.db.Query("update account set first_name = $2, last_name=$3 where id = $1", account.ID, account.FirstName, account.LastName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query("select * from account where id = $1", account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		return scanIntoAccount(rows)
+	}
+
+	return nil, types.NotFoundError(fmt.Sprintf("account %d not found", account.ID))
+}
+
+func (s *PostgresStore) DeleteAccount(id int) error {
+	_, err := s.db.Query("delete from account where id = $1", id)
+	return err
+}
+
+// TransferToAccount moves Amount from FromAccount to ToAccount inside a
+// serializable transaction. Both account rows are locked in ascending id
+// order before either balance is touched, so two transfers between the same
+// pair of accounts can never deadlock each other, and a concurrent reader
+// never observes a half-applied transfer.
+func (s *PostgresStore) TransferToAccount(detail types.TransferRequest) (*types.TransferResult, error) {
+	tx, err := s.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	firstID, secondID := detail.FromAccount, detail.ToAccount
+	if firstID > secondID {
+		firstID, secondID = secondID, firstID
+	}
+	if _, err := tx.Exec("select id from account where id = $1 for update", firstID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("select id from account where id = $1 for update", secondID); err != nil {
+		return nil, err
+	}
+
+	from, err := getAccountTx(tx, detail.FromAccount)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getAccountTx(tx, detail.ToAccount); err != nil {
+		return nil, err
+	}
+	if from.Balance < detail.Amount {
+		return nil, types.InsufficientFundsError(fmt.Sprintf("account %d has insufficient funds", from.ID))
+	}
+
+	if _, err := tx.Exec("update account set balance = balance - $1 where id = $2", detail.Amount, detail.FromAccount); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec("update account set balance = balance + $1 where id = $2", detail.Amount, detail.ToAccount); err != nil {
+		return nil, err
+	}
+
+	transfer := new(types.Transfer)
+	row := tx.QueryRow(
+		`insert into transfer (from_account, to_account, amount, created_at)
+		 values ($1, $2, $3, now())
+		 returning id, from_account, to_account, amount, created_at`,
+		detail.FromAccount, detail.ToAccount, detail.Amount)
+	if err := row.Scan(&transfer.ID, &transfer.FromAccount, &transfer.ToAccount, &transfer.Amount, &transfer.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	from, err = getAccountTx(tx, detail.FromAccount)
+	if err != nil {
+		return nil, err
+	}
+	to, err := getAccountTx(tx, detail.ToAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &types.TransferResult{From: from, To: to, Transfer: transfer}, nil
+}
+
+func (s *PostgresStore) GetTransfersByAccount(id int) ([]*types.Transfer, error) {
+	rows, err := s.db.Query(
+		`select id, from_account, to_account, amount, created_at
+		 from transfer
+		 where from_account = $1 or to_account = $1
+		 order by created_at`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []*types.Transfer{}
+	for rows.Next() {
+		transfer := new(types.Transfer)
+		if err := rows.Scan(&transfer.ID, &transfer.FromAccount, &transfer.ToAccount, &transfer.Amount, &transfer.CreatedAt); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows, so scanIntoAccount
+// works whether it's reading a single locked row inside a transaction or
+// iterating a result set.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanIntoAccount(row rowScanner) (*types.Account, error) {
+	account := new(types.Account)
+	err := row.Scan(
+		&account.ID,
+		&account.FirstName,
+		&account.LastName,
+		&account.Number,
+		&account.Balance,
+		&account.Password,
+		&account.Role,
+		&account.CreateAt)
+
+	return account, err
+}
+
+// getAccountTx fetches an account inside tx, translating a missing row into
+// a types.NotFoundError so a transfer against an unknown account surfaces
+// the same structured error as every other account lookup instead of a raw
+// sql.ErrNoRows.
+func getAccountTx(tx *sql.Tx, id int) (*types.Account, error) {
+	account, err := scanIntoAccount(tx.QueryRow("select * from account where id = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, types.NotFoundError(fmt.Sprintf("account %d not found", id))
+	}
+	return account, err
+}
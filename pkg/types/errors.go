@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// APIError is the structured error returned to clients. Status drives the
+// HTTP response code, Code is a stable machine-readable identifier for
+// programmatic handling, and Fields carries per-field validation failures
+// when Code is "invalid_request".
+type APIError struct {
+	Status  int               `json:"-"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+func NewAPIError(status int, code, message string) *APIError {
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+func InvalidRequestError(fields map[string]string) *APIError {
+	return &APIError{
+		Status:  http.StatusUnprocessableEntity,
+		Code:    "invalid_request",
+		Message: "request failed validation",
+		Fields:  fields,
+	}
+}
+
+func NotFoundError(message string) *APIError {
+	return NewAPIError(http.StatusNotFound, "not_found", message)
+}
+
+func InsufficientFundsError(message string) *APIError {
+	return NewAPIError(http.StatusConflict, "insufficient_funds", message)
+}
+
+func UnauthorizedError(message string) *APIError {
+	return NewAPIError(http.StatusUnauthorized, "unauthorized", message)
+}
+
+func MethodNotAllowedError(method string) *APIError {
+	return NewAPIError(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed: "+method)
+}
+
+func WriteJSON(w http.ResponseWriter, status int, v any) error {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"math/rand"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Account struct {
+	ID        int       `json:"id"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	Number    int64     `json:"number"`
+	Balance   int64     `json:"balance"`
+	Password  string    `json:"-"`
+	Role      string    `json:"role"`
+	CreateAt  time.Time `json:"createdAt"`
+}
+
+type CreateAccountRequest struct {
+	FirstName string `json:"firstName" validate:"required"`
+	LastName  string `json:"lastName" validate:"required"`
+	Password  string `json:"password" validate:"required,min=8"`
+}
+
+type LoginRequest struct {
+	Number   int64  `json:"number"`
+	Password string `json:"password"`
+}
+
+type TransferRequest struct {
+	FromAccount int   `json:"fromAccount" validate:"required"`
+	ToAccount   int   `json:"toAccount" validate:"required,nefield=FromAccount"`
+	Amount      int64 `json:"amount" validate:"required,gt=0"`
+}
+
+// Transfer is a ledger entry recording a completed transfer between two
+// accounts.
+type Transfer struct {
+	ID          int       `json:"id"`
+	FromAccount int       `json:"fromAccount"`
+	ToAccount   int       `json:"toAccount"`
+	Amount      int64     `json:"amount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// TransferResult is what a successful transfer returns: both accounts as
+// they now stand, plus the ledger entry that was recorded for it.
+type TransferResult struct {
+	From     *Account  `json:"from"`
+	To       *Account  `json:"to"`
+	Transfer *Transfer `json:"transfer"`
+}
+
+// PaginatedResponse wraps a page of results with the paging info the client
+// needs to fetch the next one.
+type PaginatedResponse struct {
+	Data  any `json:"data"`
+	Page  int `json:"page"`
+	Total int `json:"total"`
+}
+
+func NewAccount(firstName, lastName, password string) (*Account, error) {
+	encpw, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Account{
+		FirstName: firstName,
+		LastName:  lastName,
+		Number:    int64(rand.Intn(1000000)),
+		Password:  string(encpw),
+		Role:      "user",
+		CreateAt:  time.Now().UTC(),
+	}, nil
+}
+
+// NewAdminAccount builds the bootstrap admin account seeded by
+// PostgresStore.Init on an empty account table.
+func NewAdminAccount(password string) (*Account, error) {
+	account, err := NewAccount("admin", "admin", password)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Role = "admin"
+	return account, nil
+}
+
+func (a *Account) ValidPassword(pw string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(a.Password), []byte(pw)) == nil
+}